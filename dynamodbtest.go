@@ -0,0 +1,134 @@
+package sqltest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	defaultDynamoDBLocalImage = "amazon/dynamodb-local"
+	defaultDynamoDBLocalTag   = "latest"
+)
+
+// NewDynamoDBLocal starts a DynamoDB Local Docker container using the default settings and
+// returns a connected *dynamodb.Client along with a cleanup function. It uses the default
+// DynamoDB Local image ("amazon/dynamodb-local") with tag "latest". For more customization, use
+// NewDynamoDBLocalWithOptions.
+func NewDynamoDBLocal(t testing.TB) (*dynamodb.Client, func()) {
+	return NewDynamoDBLocalWithOptions(t, nil)
+}
+
+// NewDynamoDBLocalWithOptions starts a DynamoDB Local Docker container using Docker and returns
+// a connected *dynamodb.Client along with a cleanup function. It applies the default settings:
+//   - Repository: "amazon/dynamodb-local"
+//   - Tag: "latest"
+//
+// Additional RunOption functions can be provided via the runOpts parameter to override these defaults,
+// and optional host configuration functions can be provided via hostOpts. The client is configured
+// with dummy static credentials, since DynamoDB Local does not perform authentication.
+func NewDynamoDBLocalWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) (*dynamodb.Client, func()) {
+	t.Helper()
+
+	// Set default run options for DynamoDB Local.
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultDynamoDBLocalImage,
+		Tag:        defaultDynamoDBLocalTag,
+	}
+
+	// Apply any provided RunOption functions to override defaults.
+	for _, opt := range runOpts {
+		opt(defaultRunOpts)
+	}
+
+	var client *dynamodb.Client
+	readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+		client = dynamodb.New(dynamodb.Options{
+			Region:       "us-east-1",
+			Credentials:  credentials.NewStaticCredentialsProvider("dummy", "dummy", ""),
+			BaseEndpoint: aws.String(fmt.Sprintf("http://%s", resource.GetHostPort("8000/tcp"))),
+		})
+		_, err := client.ListTables(ctx, &dynamodb.ListTablesInput{})
+		return err
+	}
+
+	pool, resource, _ := startContainerFromConfig(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "8000/tcp",
+	}, readyFunc)
+
+	cleanup := func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove dynamodb-local container: %s", err)
+		}
+	}
+
+	return client, cleanup
+}
+
+// PrepDynamoDB creates the given tables and then batch-writes the given items into them. items
+// maps a table name to the list of attribute-value items to insert.
+func PrepDynamoDB(t testing.TB, client *dynamodb.Client, tables []dynamodb.CreateTableInput, items map[string][]map[string]types.AttributeValue) error {
+	t.Helper()
+
+	ctx := context.Background()
+	for i := range tables {
+		if _, err := client.CreateTable(ctx, &tables[i]); err != nil {
+			return fmt.Errorf("failed to create table '%s': %w", aws.ToString(tables[i].TableName), err)
+		}
+	}
+
+	for table, rows := range items {
+		if err := batchWriteItems(ctx, client, table, rows); err != nil {
+			return fmt.Errorf("failed to write items into table '%s': %w", table, err)
+		}
+	}
+	return nil
+}
+
+// batchWriteItems writes rows into table in batches of 25, the maximum BatchWriteItem allows.
+// Items DynamoDB reports as unprocessed (e.g. from internal throttling) are retried, bounded by
+// maxUnprocessedRetries, before giving up with an error.
+func batchWriteItems(ctx context.Context, client *dynamodb.Client, table string, rows []map[string]types.AttributeValue) error {
+	const maxBatchSize = 25
+	const maxUnprocessedRetries = 5
+
+	requests := make([]types.WriteRequest, len(rows))
+	for i, row := range rows {
+		requests[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: row}}
+	}
+
+	for len(requests) > 0 {
+		batch := requests
+		if len(batch) > maxBatchSize {
+			batch = batch[:maxBatchSize]
+		}
+		requests = requests[len(batch):]
+
+		for attempt := 0; ; attempt++ {
+			out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{table: batch},
+			})
+			if err != nil {
+				return err
+			}
+			unprocessed := out.UnprocessedItems[table]
+			if len(unprocessed) == 0 {
+				break
+			}
+			if attempt >= maxUnprocessedRetries {
+				return fmt.Errorf("%d item(s) in table %q remained unprocessed after %d retries", len(unprocessed), table, maxUnprocessedRetries)
+			}
+			batch = unprocessed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package sqltest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/vvatanabe/sqltest"
+)
+
+// TestDefaultDynamoDBLocal demonstrates using NewDynamoDBLocal with default options.
+func TestDefaultDynamoDBLocal(t *testing.T) {
+	// Start a DynamoDB Local container with default options.
+	client, cleanup := sqltest.NewDynamoDBLocal(t)
+	defer cleanup()
+
+	tables := []dynamodb.CreateTableInput{
+		{
+			TableName: awsString("users"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: awsString("id"), KeyType: types.KeyTypeHash},
+			},
+			AttributeDefinitions: []types.AttributeDefinition{
+				{AttributeName: awsString("id"), AttributeType: types.ScalarAttributeTypeS},
+			},
+			BillingMode: types.BillingModePayPerRequest,
+		},
+	}
+	items := map[string][]map[string]types.AttributeValue{
+		"users": {
+			{"id": &types.AttributeValueMemberS{Value: "1"}, "name": &types.AttributeValueMemberS{Value: "Alice"}},
+		},
+	}
+
+	// Prepare DynamoDB Local by creating the table and writing initial items.
+	if err := sqltest.PrepDynamoDB(t, client, tables, items); err != nil {
+		t.Fatalf("PrepDynamoDB failed: %v", err)
+	}
+
+	// Validate that the item was written correctly.
+	out, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: awsString("users"),
+		Key:       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to get item: %v", err)
+	}
+	name, ok := out.Item["name"].(*types.AttributeValueMemberS)
+	if !ok || name.Value != "Alice" {
+		t.Errorf("expected name 'Alice', but got '%v'", out.Item["name"])
+	}
+}
+
+// TestDynamoDBLocalWithCustomHostOptions demonstrates providing host configuration options.
+func TestDynamoDBLocalWithCustomHostOptions(t *testing.T) {
+	// Host option to set AutoRemove to true.
+	autoRemove := func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+	}
+
+	// Start a DynamoDB Local container with the AutoRemove option.
+	client, cleanup := sqltest.NewDynamoDBLocalWithOptions(t, nil, autoRemove)
+	defer cleanup()
+
+	_, err := client.ListTables(context.Background(), &dynamodb.ListTablesInput{})
+	if err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+}
+
+func awsString(s string) *string {
+	return &s
+}
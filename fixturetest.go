@@ -0,0 +1,209 @@
+package sqltest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is a set of rows to seed into a single table. Rows are inserted in the order given,
+// each through a driver-aware INSERT built from the row's own keys.
+type Fixture struct {
+	// Table is the name of the table to insert Rows into.
+	Table string
+	// Rows are the rows to insert, one map per row, keyed by column name.
+	Rows []map[string]any
+	// Truncate, if true, truncates Table before inserting Rows. On Postgres this also resets
+	// identity sequences and cascades to dependent tables; on MySQL it resets AUTO_INCREMENT.
+	Truncate bool
+}
+
+// fixtureFile is the on-disk shape of a fixture YAML/JSON file.
+type fixtureFile struct {
+	Table    string           `yaml:"table" json:"table"`
+	Rows     []map[string]any `yaml:"rows" json:"rows"`
+	Truncate bool             `yaml:"truncate" json:"truncate"`
+}
+
+// LoadFixtures loads one Fixture per path from YAML (.yaml, .yml) or JSON (.json) files. Each
+// file is expected to contain a "table" name and a list of "rows".
+func LoadFixtures(paths ...string) ([]Fixture, error) {
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		fixture, err := loadFixtureFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load fixture %q: %w", path, err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, nil
+}
+
+// loadFixtureFile reads and parses a single fixture file, choosing YAML or JSON based on its
+// extension.
+func loadFixtureFile(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	var file fixtureFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return Fixture{}, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return Fixture{}, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+	default:
+		return Fixture{}, fmt.Errorf("unsupported fixture file extension %q", ext)
+	}
+
+	return Fixture{Table: file.Table, Rows: file.Rows, Truncate: file.Truncate}, nil
+}
+
+// applyFixture truncates (if requested) and inserts fixture.Rows into fixture.Table, quoting
+// identifiers and binding placeholders appropriately for the database's driver. After inserting
+// into a Postgres table, it resyncs any serial/identity sequences so subsequently inserted rows
+// don't collide with the seeded IDs.
+func applyFixture(db *sql.DB, fixture Fixture) error {
+	driverName := driverNameOf(db)
+
+	if fixture.Truncate {
+		if err := truncateTable(db, driverName, fixture.Table); err != nil {
+			return fmt.Errorf("failed to truncate: %w", err)
+		}
+	}
+
+	for i, row := range fixture.Rows {
+		if err := insertFixtureRow(db, driverName, fixture.Table, row); err != nil {
+			return fmt.Errorf("failed to insert row %d: %w", i, err)
+		}
+	}
+
+	if driverName == "postgres" && len(fixture.Rows) > 0 {
+		if err := syncPostgresSequences(db, fixture.Table); err != nil {
+			return fmt.Errorf("failed to sync sequences: %w", err)
+		}
+	}
+	return nil
+}
+
+// driverNameOf returns "mysql" or "postgres" based on db's registered driver, or "" if it's
+// neither of the two drivers this package supports.
+func driverNameOf(db *sql.DB) string {
+	switch db.Driver().(type) {
+	case *mysql.MySQLDriver:
+		return "mysql"
+	case *pq.Driver:
+		return "postgres"
+	default:
+		return ""
+	}
+}
+
+// quoteIdentifier quotes a table or column name for the given driver: backticks for MySQL,
+// double quotes for Postgres (and as the fallback for any other driver).
+func quoteIdentifier(driverName, name string) string {
+	if driverName == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+// placeholder returns the positional parameter placeholder for the given driver: "$N" for
+// Postgres, "?" for MySQL (and as the fallback for any other driver).
+func placeholder(driverName string, position int) string {
+	if driverName == "postgres" {
+		return fmt.Sprintf("$%d", position)
+	}
+	return "?"
+}
+
+// insertFixtureRow builds and executes a single parameterized INSERT for row, ordering its
+// columns deterministically since Go map iteration order is randomized.
+func insertFixtureRow(db *sql.DB, driverName, table string, row map[string]any) error {
+	cols := make([]string, 0, len(row))
+	for col := range row {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	quotedCols := make([]string, len(cols))
+	placeholders := make([]string, len(cols))
+	args := make([]any, len(cols))
+	for i, col := range cols {
+		quotedCols[i] = quoteIdentifier(driverName, col)
+		placeholders[i] = placeholder(driverName, i+1)
+		args[i] = row[col]
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(driverName, table), strings.Join(quotedCols, ", "), strings.Join(placeholders, ", "))
+	_, err := db.Exec(query, args...)
+	return err
+}
+
+// truncateTable empties table, resetting auto-generated IDs: RESTART IDENTITY CASCADE on
+// Postgres, TRUNCATE + AUTO_INCREMENT reset on MySQL.
+func truncateTable(db *sql.DB, driverName, table string) error {
+	quoted := quoteIdentifier(driverName, table)
+	switch driverName {
+	case "postgres":
+		_, err := db.Exec(fmt.Sprintf("TRUNCATE %s RESTART IDENTITY CASCADE", quoted))
+		return err
+	case "mysql":
+		if _, err := db.Exec(fmt.Sprintf("TRUNCATE %s", quoted)); err != nil {
+			return err
+		}
+		_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = 1", quoted))
+		return err
+	default:
+		_, err := db.Exec(fmt.Sprintf("TRUNCATE %s", quoted))
+		return err
+	}
+}
+
+// syncPostgresSequences advances every serial/identity sequence backing table to the current
+// max value of its column, so that rows inserted after a fixture seed don't collide with the
+// seeded IDs. Only serial columns (default nextval(...)) and identity columns are touched.
+func syncPostgresSequences(db *sql.DB, table string) error {
+	rows, err := db.Query(
+		`SELECT column_name FROM information_schema.columns WHERE table_name = $1 AND (column_default LIKE 'nextval(%' OR is_identity = 'YES')`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return err
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, column := range columns {
+		query := fmt.Sprintf(
+			`SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE((SELECT MAX(%s) FROM %s), 1))`,
+			table, column, quoteIdentifier("postgres", column), quoteIdentifier("postgres", table))
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
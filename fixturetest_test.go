@@ -0,0 +1,76 @@
+package sqltest
+
+import "testing"
+
+func TestQuoteIdentifier(t *testing.T) {
+	if got, want := quoteIdentifier("mysql", "users"), "`users`"; got != want {
+		t.Errorf("quoteIdentifier(mysql, users) = %q, want %q", got, want)
+	}
+	if got, want := quoteIdentifier("postgres", "users"), `"users"`; got != want {
+		t.Errorf("quoteIdentifier(postgres, users) = %q, want %q", got, want)
+	}
+}
+
+func TestPlaceholder(t *testing.T) {
+	if got, want := placeholder("mysql", 1), "?"; got != want {
+		t.Errorf("placeholder(mysql, 1) = %q, want %q", got, want)
+	}
+	if got, want := placeholder("postgres", 2), "$2"; got != want {
+		t.Errorf("placeholder(postgres, 2) = %q, want %q", got, want)
+	}
+}
+
+func TestLoadFixturesYAML(t *testing.T) {
+	fixtures, err := LoadFixtures("testdata/fixture_users.yaml")
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	if len(fixtures) != 1 {
+		t.Fatalf("len(fixtures) = %d, want 1", len(fixtures))
+	}
+
+	fixture := fixtures[0]
+	if fixture.Table != "users" {
+		t.Errorf("Table = %q, want %q", fixture.Table, "users")
+	}
+	if !fixture.Truncate {
+		t.Errorf("Truncate = false, want true")
+	}
+	if len(fixture.Rows) != 2 {
+		t.Fatalf("len(Rows) = %d, want 2", len(fixture.Rows))
+	}
+	if got, want := fixture.Rows[0]["name"], "Alice"; got != want {
+		t.Errorf("Rows[0][name] = %v, want %v", got, want)
+	}
+}
+
+func TestLoadFixturesJSON(t *testing.T) {
+	fixtures, err := LoadFixtures("testdata/fixture_products.json")
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	if len(fixtures) != 1 {
+		t.Fatalf("len(fixtures) = %d, want 1", len(fixtures))
+	}
+
+	fixture := fixtures[0]
+	if fixture.Table != "products" {
+		t.Errorf("Table = %q, want %q", fixture.Table, "products")
+	}
+	if fixture.Truncate {
+		t.Errorf("Truncate = true, want false")
+	}
+	if len(fixture.Rows) != 1 {
+		t.Fatalf("len(Rows) = %d, want 1", len(fixture.Rows))
+	}
+	if got, want := fixture.Rows[0]["name"], "Widget"; got != want {
+		t.Errorf("Rows[0][name] = %v, want %v", got, want)
+	}
+}
+
+func TestLoadFixturesUnsupportedExtension(t *testing.T) {
+	_, err := LoadFixtures("testdata/fixture_unsupported.txt")
+	if err == nil {
+		t.Fatal("LoadFixtures() error = nil, want an error for an unsupported extension")
+	}
+}
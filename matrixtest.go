@@ -0,0 +1,315 @@
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/redis/go-redis/v9"
+)
+
+// ImageSpec identifies a Docker image/tag pair to run a matrix subtest against.
+// Repository is optional; when empty, the caller's default repository is used.
+type ImageSpec struct {
+	// Repository overrides the default Docker repository for this spec (e.g. "mysql").
+	Repository string
+	// Tag selects the image version to run (e.g. "8.0").
+	Tag string
+}
+
+// subtestName returns the name used for t.Run, falling back to the spec's index
+// when neither Repository nor Tag is set.
+func (s ImageSpec) subtestName(index int) string {
+	switch {
+	case s.Repository != "" && s.Tag != "":
+		return fmt.Sprintf("%s_%s", s.Repository, s.Tag)
+	case s.Tag != "":
+		return s.Tag
+	case s.Repository != "":
+		return s.Repository
+	default:
+		return fmt.Sprintf("spec_%d", index)
+	}
+}
+
+// RunMatrix runs fn as a parallel t.Run subtest for every spec in specs. factory is invoked once
+// per spec, with that spec's index in specs, to start the corresponding container (or other
+// resource) and must return a connected instance along with its cleanup function; RunMatrix
+// registers the cleanup via t.Cleanup. The index is threaded through so factory implementations
+// can name containers uniquely even when two specs share a Tag but differ in Repository. This is
+// the generic entry point third-party engines can plug into; RunMySQLMatrix, RunPostgresMatrix,
+// RunMemcachedMatrix, and RunRedisMatrix are thin wrappers around it for the built-in engines.
+func RunMatrix(t *testing.T, specs []ImageSpec, factory func(t *testing.T, spec ImageSpec, index int) (any, func()), fn func(t *testing.T, spec ImageSpec, instance any)) {
+	t.Helper()
+
+	for i, spec := range specs {
+		i, spec := i, spec
+		t.Run(spec.subtestName(i), func(t *testing.T) {
+			t.Parallel()
+
+			instance, cleanup := factory(t, spec, i)
+			t.Cleanup(cleanup)
+
+			fn(t, spec, instance)
+		})
+	}
+}
+
+// matrixPool creates a single dockertest.Pool shared across every spec in a matrix run,
+// so all containers are managed through one Docker client instead of one per spec.
+func matrixPool(t testing.TB) *dockertest.Pool {
+	t.Helper()
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Fatalf("failed to connect to docker: %s", err)
+	}
+	return pool
+}
+
+// runDockerDBMatrix starts a database container for each spec against the shared pool, applying
+// runOpts/hostOpts and naming each container after its index so specs never collide, even when
+// two specs share a Tag but differ in Repository.
+func runDockerDBMatrix(t *testing.T, pool *dockertest.Pool, specs []ImageSpec, defaultRunOpts *dockertest.RunOptions, containerPort, driverName string, dsnFunc func(actualPort string) string, runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) func(t *testing.T, spec ImageSpec, index int) (any, func()) {
+	return func(t *testing.T, spec ImageSpec, index int) (any, func()) {
+		t.Helper()
+
+		opts := *defaultRunOpts
+		if spec.Repository != "" {
+			opts.Repository = spec.Repository
+		}
+		if spec.Tag != "" {
+			opts.Tag = spec.Tag
+		}
+		opts.Name = fmt.Sprintf("%s-%d-matrix", driverName, index)
+		for _, opt := range runOpts {
+			opt(&opts)
+		}
+
+		var db *sql.DB
+		readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+			var err error
+			db, err = sql.Open(driverName, dsnFunc(resource.GetHostPort(containerPort)))
+			if err != nil {
+				return err
+			}
+			return db.PingContext(ctx)
+		}
+
+		resource, _ := startContainerInPool(t, pool, ContainerConfig{
+			RunOptions:    &opts,
+			HostOpts:      hostOpts,
+			ContainerPort: containerPort,
+		}, readyFunc)
+
+		cleanup := func() {
+			if err := db.Close(); err != nil {
+				t.Logf("failed to close DB: %s", err)
+			}
+			if err := pool.Purge(resource); err != nil {
+				t.Logf("failed to remove %s container: %s", driverName, err)
+			}
+		}
+
+		return db, cleanup
+	}
+}
+
+// RunMySQLMatrix runs fn as a parallel subtest against a freshly started MySQL container for
+// each spec in specs. All containers share a single dockertest.Pool. Specs with an empty Tag
+// fall back to the default MySQL tag; use RunMySQLMatrixWithOptions to override RunOptions or
+// host options per run.
+func RunMySQLMatrix(t *testing.T, specs []ImageSpec, fn func(t *testing.T, db *sql.DB)) {
+	t.Helper()
+	RunMySQLMatrixWithOptions(t, specs, fn, nil)
+}
+
+// RunMySQLMatrixWithOptions is like RunMySQLMatrix but accepts RunOption and host configuration
+// functions that are applied to every spec's RunOptions, in addition to the spec's own
+// Repository/Tag override.
+func RunMySQLMatrixWithOptions(t *testing.T, specs []ImageSpec, fn func(t *testing.T, db *sql.DB), runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) {
+	t.Helper()
+
+	pool := matrixPool(t)
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultMySQLImage,
+		Tag:        defaultMySQLTag,
+		Env: []string{
+			"MYSQL_ROOT_PASSWORD=secret",
+			"MYSQL_DATABASE=test",
+		},
+	}
+	pass := getEnvValue(defaultRunOpts.Env, "MYSQL_ROOT_PASSWORD")
+	dbName := getEnvValue(defaultRunOpts.Env, "MYSQL_DATABASE")
+
+	factory := runDockerDBMatrix(t, pool, specs, defaultRunOpts, "3306/tcp", "mysql", func(actualPort string) string {
+		return fmt.Sprintf("root:%s@tcp(%s)/%s?parseTime=true", pass, actualPort, dbName)
+	}, runOpts, hostOpts...)
+
+	RunMatrix(t, specs, factory, func(t *testing.T, spec ImageSpec, instance any) {
+		fn(t, instance.(*sql.DB))
+	})
+}
+
+// RunPostgresMatrix runs fn as a parallel subtest against a freshly started PostgreSQL container
+// for each spec in specs. All containers share a single dockertest.Pool. Use
+// RunPostgresMatrixWithOptions to override RunOptions or host options per run.
+func RunPostgresMatrix(t *testing.T, specs []ImageSpec, fn func(t *testing.T, db *sql.DB)) {
+	t.Helper()
+	RunPostgresMatrixWithOptions(t, specs, fn, nil)
+}
+
+// RunPostgresMatrixWithOptions is like RunPostgresMatrix but accepts RunOption and host
+// configuration functions that are applied to every spec's RunOptions.
+func RunPostgresMatrixWithOptions(t *testing.T, specs []ImageSpec, fn func(t *testing.T, db *sql.DB), runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) {
+	t.Helper()
+
+	pool := matrixPool(t)
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultPostgresImage,
+		Tag:        defaultPostgresTag,
+		Env: []string{
+			"POSTGRES_PASSWORD=secret",
+			"POSTGRES_DB=test",
+		},
+	}
+	pass := getEnvValue(defaultRunOpts.Env, "POSTGRES_PASSWORD")
+	dbName := getEnvValue(defaultRunOpts.Env, "POSTGRES_DB")
+
+	factory := runDockerDBMatrix(t, pool, specs, defaultRunOpts, "5432/tcp", "postgres", func(actualPort string) string {
+		return fmt.Sprintf("postgres://postgres:%s@%s/%s?sslmode=disable", pass, actualPort, dbName)
+	}, runOpts, hostOpts...)
+
+	RunMatrix(t, specs, factory, func(t *testing.T, spec ImageSpec, instance any) {
+		fn(t, instance.(*sql.DB))
+	})
+}
+
+// RunMemcachedMatrix runs fn as a parallel subtest against a freshly started Memcached container
+// for each spec in specs. All containers share a single dockertest.Pool. Use
+// RunMemcachedMatrixWithOptions to override RunOptions or host options per run.
+func RunMemcachedMatrix(t *testing.T, specs []ImageSpec, fn func(t *testing.T, client *memcache.Client)) {
+	t.Helper()
+	RunMemcachedMatrixWithOptions(t, specs, fn, nil)
+}
+
+// RunMemcachedMatrixWithOptions is like RunMemcachedMatrix but accepts RunOption and host
+// configuration functions that are applied to every spec's RunOptions.
+func RunMemcachedMatrixWithOptions(t *testing.T, specs []ImageSpec, fn func(t *testing.T, client *memcache.Client), runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) {
+	t.Helper()
+
+	pool := matrixPool(t)
+	factory := func(t *testing.T, spec ImageSpec, index int) (any, func()) {
+		t.Helper()
+
+		opts := &dockertest.RunOptions{
+			Repository: defaultMemcachedImage,
+			Tag:        defaultMemcachedTag,
+		}
+		if spec.Repository != "" {
+			opts.Repository = spec.Repository
+		}
+		if spec.Tag != "" {
+			opts.Tag = spec.Tag
+		}
+		opts.Name = fmt.Sprintf("memcached-%d-matrix", index)
+		for _, opt := range runOpts {
+			opt(opts)
+		}
+
+		var client *memcache.Client
+		readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+			client = memcache.New(resource.GetHostPort("11211/tcp"))
+			testKey := "test_connection"
+			if err := client.Set(&memcache.Item{Key: testKey, Value: []byte("test_value")}); err != nil {
+				return err
+			}
+			time.Sleep(100 * time.Millisecond)
+			_, err := client.Get(testKey)
+			return err
+		}
+
+		resource, _ := startContainerInPool(t, pool, ContainerConfig{
+			RunOptions:    opts,
+			HostOpts:      hostOpts,
+			ContainerPort: "11211/tcp",
+		}, readyFunc)
+
+		cleanup := func() {
+			if err := pool.Purge(resource); err != nil {
+				t.Logf("failed to remove memcached container: %s", err)
+			}
+		}
+
+		return client, cleanup
+	}
+
+	RunMatrix(t, specs, factory, func(t *testing.T, spec ImageSpec, instance any) {
+		fn(t, instance.(*memcache.Client))
+	})
+}
+
+// RunRedisMatrix runs fn as a parallel subtest against a freshly started Redis container for
+// each spec in specs. All containers share a single dockertest.Pool. Use
+// RunRedisMatrixWithOptions to override RunOptions or host options per run.
+func RunRedisMatrix(t *testing.T, specs []ImageSpec, fn func(t *testing.T, client *redis.Client)) {
+	t.Helper()
+	RunRedisMatrixWithOptions(t, specs, fn, nil)
+}
+
+// RunRedisMatrixWithOptions is like RunRedisMatrix but accepts RunOption and host configuration
+// functions that are applied to every spec's RunOptions.
+func RunRedisMatrixWithOptions(t *testing.T, specs []ImageSpec, fn func(t *testing.T, client *redis.Client), runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) {
+	t.Helper()
+
+	pool := matrixPool(t)
+	factory := func(t *testing.T, spec ImageSpec, index int) (any, func()) {
+		t.Helper()
+
+		opts := &dockertest.RunOptions{
+			Repository: defaultRedisImage,
+			Tag:        defaultRedisTag,
+		}
+		if spec.Repository != "" {
+			opts.Repository = spec.Repository
+		}
+		if spec.Tag != "" {
+			opts.Tag = spec.Tag
+		}
+		opts.Name = fmt.Sprintf("redis-%d-matrix", index)
+		for _, opt := range runOpts {
+			opt(opts)
+		}
+
+		var client *redis.Client
+		readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+			client = redis.NewClient(&redis.Options{Addr: resource.GetHostPort("6379/tcp")})
+			return client.Ping(ctx).Err()
+		}
+
+		resource, _ := startContainerInPool(t, pool, ContainerConfig{
+			RunOptions:    opts,
+			HostOpts:      hostOpts,
+			ContainerPort: "6379/tcp",
+		}, readyFunc)
+
+		cleanup := func() {
+			if err := client.Close(); err != nil {
+				t.Logf("failed to close redis client: %s", err)
+			}
+			if err := pool.Purge(resource); err != nil {
+				t.Logf("failed to remove redis container: %s", err)
+			}
+		}
+
+		return client, cleanup
+	}
+
+	RunMatrix(t, specs, factory, func(t *testing.T, spec ImageSpec, instance any) {
+		fn(t, instance.(*redis.Client))
+	})
+}
@@ -0,0 +1,74 @@
+package sqltest_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/vvatanabe/sqltest"
+)
+
+// TestRunMySQLMatrix demonstrates running the same test function against several MySQL versions.
+func TestRunMySQLMatrix(t *testing.T) {
+	specs := []sqltest.ImageSpec{
+		{Tag: "5.7"},
+		{Tag: "8.0"},
+	}
+
+	sqltest.RunMySQLMatrix(t, specs, func(t *testing.T, db *sql.DB) {
+		schema := `
+		CREATE TABLE IF NOT EXISTS users (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL
+		);
+		`
+		insertStmt := `INSERT INTO users (name) VALUES ('Alice');`
+
+		if err := sqltest.PrepDatabase(t, db, sqltest.InitialDBSetup{
+			SchemaSQL:   schema,
+			InitialData: []string{insertStmt},
+		}); err != nil {
+			t.Fatalf("PrepDatabase failed: %v", err)
+		}
+
+		var name string
+		if err := db.QueryRow("SELECT name FROM users WHERE name = ?", "Alice").Scan(&name); err != nil {
+			t.Fatalf("failed to retrieve data: %v", err)
+		}
+		if name != "Alice" {
+			t.Errorf("expected name 'Alice', but got '%s'", name)
+		}
+	})
+}
+
+// TestRunPostgresMatrix demonstrates running the same test function against several PostgreSQL versions.
+func TestRunPostgresMatrix(t *testing.T) {
+	specs := []sqltest.ImageSpec{
+		{Tag: "12"},
+		{Tag: "13"},
+	}
+
+	sqltest.RunPostgresMatrix(t, specs, func(t *testing.T, db *sql.DB) {
+		schema := `
+		CREATE TABLE IF NOT EXISTS users (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL
+		);
+		`
+		insertStmt := `INSERT INTO users (name) VALUES ('Charlie');`
+
+		if err := sqltest.PrepDatabase(t, db, sqltest.InitialDBSetup{
+			SchemaSQL:   schema,
+			InitialData: []string{insertStmt},
+		}); err != nil {
+			t.Fatalf("PrepDatabase failed: %v", err)
+		}
+
+		var name string
+		if err := db.QueryRow("SELECT name FROM users WHERE name = $1", "Charlie").Scan(&name); err != nil {
+			t.Fatalf("failed to retrieve data: %v", err)
+		}
+		if name != "Charlie" {
+			t.Errorf("expected name 'Charlie', but got '%s'", name)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package sqltest
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -43,50 +44,29 @@ func NewMemcachedWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func
 		opt(defaultRunOpts)
 	}
 
-	pool, err := dockertest.NewPool("")
-	if err != nil {
-		t.Fatalf("failed to connect to docker: %s", err)
-	}
-
-	// Pass optional host configuration options
-	resource, err := pool.RunWithOptions(defaultRunOpts, hostOpts...)
-	if err != nil {
-		t.Fatalf("failed to start memcached container: %s", err)
-	}
-
-	// Get the host port that was assigned to the container's 11211 port
-	actualPort := resource.GetHostPort("11211/tcp")
-	if actualPort == "" {
-		_ = pool.Purge(resource)
-		t.Fatalf("no host port was assigned for the memcached container")
-	}
-	t.Logf("memcached container is running on host port '%s'", actualPort)
-
-	// Create a memcache client
 	var client *memcache.Client
-	if err = pool.Retry(func() error {
-		client = memcache.New(actualPort)
+	readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+		client = memcache.New(resource.GetHostPort("11211/tcp"))
 		// Test the connection by setting and getting a value
 		testKey := "test_connection"
 		testValue := []byte("test_value")
-		err := client.Set(&memcache.Item{
-			Key:   testKey,
-			Value: testValue,
-		})
-		if err != nil {
+		if err := client.Set(&memcache.Item{Key: testKey, Value: testValue}); err != nil {
 			return err
 		}
 
 		// Wait a moment to ensure the value is stored
 		time.Sleep(100 * time.Millisecond)
 
-		_, err = client.Get(testKey)
+		_, err := client.Get(testKey)
 		return err
-	}); err != nil {
-		_ = pool.Purge(resource)
-		t.Fatalf("failed to connect to memcached: %s", err)
 	}
 
+	pool, resource, _ := startContainerFromConfig(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "11211/tcp",
+	}, readyFunc)
+
 	cleanup := func() {
 		if err := pool.Purge(resource); err != nil {
 			t.Logf("failed to remove memcached container: %s", err)
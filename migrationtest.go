@@ -0,0 +1,339 @@
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Migrator applies and reverts schema migrations against a *sql.DB. Up and Down are expected to
+// be idempotent: calling Up twice against the same database must only apply migrations that
+// have not already been recorded as applied.
+type Migrator interface {
+	// Up applies every pending migration, in version order.
+	Up(ctx context.Context, db *sql.DB) error
+	// Down reverts every applied migration, in reverse version order. It stops with an error at
+	// the first applied migration that has no down script.
+	Down(ctx context.Context, db *sql.DB) error
+}
+
+// migration is a single parsed migration script.
+type migration struct {
+	Version int64
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+// FileMigrator is the default Migrator. It reads "NNN_name.up.sql" / "NNN_name.down.sql" files,
+// applies them in ascending version order, and tracks the highest applied version in a
+// "schema_migrations" table (version bigint, dirty bool) so re-running Up against a reused
+// container only applies what's missing.
+type FileMigrator struct {
+	fsys    fs.FS
+	files   []string
+	loadErr error
+}
+
+// MigrationsFromDir returns a FileMigrator that reads "NNN_name.up.sql" / "NNN_name.down.sql"
+// files from dir on the local filesystem, in lexicographic order.
+func MigrationsFromDir(dir string) *FileMigrator {
+	return &FileMigrator{fsys: os.DirFS(dir)}
+}
+
+// MigrationsFromFS returns a FileMigrator that reads migration files from dir within fsys, e.g.
+// an embed.FS produced by an //go:embed directive.
+func MigrationsFromFS(fsys fs.FS, dir string) *FileMigrator {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return &FileMigrator{loadErr: fmt.Errorf("failed to scope migrations to dir %q: %w", dir, err)}
+	}
+	return &FileMigrator{fsys: sub}
+}
+
+// MigrationsFromFiles returns a FileMigrator that reads migration files from the given explicit
+// list of paths, rather than an entire directory.
+func MigrationsFromFiles(files []string) *FileMigrator {
+	return &FileMigrator{files: files}
+}
+
+// schemaMigrationsTable is the name of the table FileMigrator uses to track the applied version.
+const schemaMigrationsTable = "schema_migrations"
+
+// Up implements Migrator.
+func (m *FileMigrator) Up(ctx context.Context, db *sql.DB) error {
+	if err := ensureSchemaMigrationsTable(ctx, db); err != nil {
+		return err
+	}
+
+	version, dirty, err := schemaMigrationsVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema_migrations is dirty at version %d; fix the database manually before retrying", version)
+	}
+
+	migrations, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range migrations {
+		if mig.Version <= version {
+			continue
+		}
+		if err := applyMigration(ctx, db, mig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down implements Migrator.
+func (m *FileMigrator) Down(ctx context.Context, db *sql.DB) error {
+	if err := ensureSchemaMigrationsTable(ctx, db); err != nil {
+		return err
+	}
+
+	version, dirty, err := schemaMigrationsVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema_migrations is dirty at version %d; fix the database manually before retrying", version)
+	}
+
+	migrations, err := m.load()
+	if err != nil {
+		return err
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version > migrations[j].Version })
+
+	for _, mig := range migrations {
+		if mig.Version > version {
+			continue
+		}
+		if mig.DownSQL == "" {
+			// Reverting any earlier migration would rewrite the tracked version past this one
+			// without actually undoing it, leaving the database and schema_migrations out of
+			// sync and breaking the idempotency guarantee documented on Migrator.
+			return fmt.Errorf("cannot revert migration %d (%s): it has no down script", mig.Version, mig.Name)
+		}
+		if err := revertMigration(ctx, db, mig, previousVersion(migrations, mig.Version)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previousVersion returns the highest migration version below version, or 0 if there is none.
+func previousVersion(migrations []migration, version int64) int64 {
+	var prev int64
+	for _, mig := range migrations {
+		if mig.Version < version && mig.Version > prev {
+			prev = mig.Version
+		}
+	}
+	return prev
+}
+
+// applyMigration marks mig.Version dirty, executes its UpSQL inside a transaction, then clears
+// the dirty flag.
+func applyMigration(ctx context.Context, db *sql.DB, mig migration) error {
+	if err := setSchemaMigrationsVersion(ctx, db, mig.Version, true); err != nil {
+		return fmt.Errorf("failed to record migration %d (%s) as pending: %w", mig.Version, mig.Name, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %d (%s): %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, mig.UpSQL); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("migration %d (%s) failed: %w", mig.Version, mig.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d (%s): %w", mig.Version, mig.Name, err)
+	}
+
+	if err := setSchemaMigrationsDirty(ctx, db, false); err != nil {
+		return fmt.Errorf("failed to clear dirty flag after migration %d (%s): %w", mig.Version, mig.Name, err)
+	}
+	return nil
+}
+
+// revertMigration marks the database dirty at mig.Version, executes its DownSQL inside a
+// transaction, then records prevVersion as the new applied version.
+func revertMigration(ctx context.Context, db *sql.DB, mig migration, prevVersion int64) error {
+	if err := setSchemaMigrationsDirty(ctx, db, true); err != nil {
+		return fmt.Errorf("failed to record migration %d (%s) as pending: %w", mig.Version, mig.Name, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %d (%s): %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, mig.DownSQL); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("down migration %d (%s) failed: %w", mig.Version, mig.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit down migration %d (%s): %w", mig.Version, mig.Name, err)
+	}
+
+	if err := setSchemaMigrationsVersion(ctx, db, prevVersion, false); err != nil {
+		return fmt.Errorf("failed to record migration %d (%s) as reverted: %w", mig.Version, mig.Name, err)
+	}
+	return nil
+}
+
+func ensureSchemaMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (version BIGINT NOT NULL, dirty BOOLEAN NOT NULL)", schemaMigrationsTable))
+	if err != nil {
+		return fmt.Errorf("failed to create %s table: %w", schemaMigrationsTable, err)
+	}
+	return nil
+}
+
+func schemaMigrationsVersion(ctx context.Context, db *sql.DB) (version int64, dirty bool, err error) {
+	err = db.QueryRowContext(ctx, fmt.Sprintf("SELECT version, dirty FROM %s LIMIT 1", schemaMigrationsTable)).Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read %s: %w", schemaMigrationsTable, err)
+	}
+	return version, dirty, nil
+}
+
+// setSchemaMigrationsVersion upserts the single schema_migrations row, since the literal values
+// here are internal (migration version numbers and booleans), formatting them directly avoids
+// the $N vs ? placeholder mismatch between the MySQL and Postgres drivers.
+func setSchemaMigrationsVersion(ctx context.Context, db *sql.DB, version int64, dirty bool) error {
+	res, err := db.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET version = %d, dirty = %t", schemaMigrationsTable, version, dirty))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	_, err = db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version, dirty) VALUES (%d, %t)", schemaMigrationsTable, version, dirty))
+	return err
+}
+
+func setSchemaMigrationsDirty(ctx context.Context, db *sql.DB, dirty bool) error {
+	_, err := db.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET dirty = %t", schemaMigrationsTable, dirty))
+	return err
+}
+
+// load reads and parses every "NNN_name.up.sql" / "NNN_name.down.sql" file the FileMigrator was
+// configured with, returning them sorted in ascending version order.
+func (m *FileMigrator) load() ([]migration, error) {
+	if m.loadErr != nil {
+		return nil, m.loadErr
+	}
+
+	var keys []string
+	var read func(key string) ([]byte, error)
+
+	switch {
+	case m.fsys != nil:
+		entries, err := fs.ReadDir(m.fsys, ".")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				keys = append(keys, entry.Name())
+			}
+		}
+		read = func(key string) ([]byte, error) { return fs.ReadFile(m.fsys, key) }
+	default:
+		keys = append(keys, m.files...)
+		read = os.ReadFile
+	}
+	sort.Strings(keys)
+
+	byVersion := make(map[int64]*migration)
+	var order []int64
+	for _, key := range keys {
+		version, name, kind, ok := parseMigrationFilename(filepath.Base(key))
+		if !ok {
+			continue
+		}
+
+		mig, exists := byVersion[version]
+		if !exists {
+			mig = &migration{Version: version, Name: name}
+			byVersion[version] = mig
+			order = append(order, version)
+		}
+
+		data, err := read(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", key, err)
+		}
+		switch kind {
+		case "up":
+			mig.UpSQL = string(data)
+		case "down":
+			mig.DownSQL = string(data)
+		}
+	}
+
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+	migrations := make([]migration, 0, len(order))
+	for _, version := range order {
+		migrations = append(migrations, *byVersion[version])
+	}
+	return migrations, nil
+}
+
+// parseMigrationFilename parses a "NNN_name.up.sql" or "NNN_name.down.sql" file name into its
+// version, name, and kind ("up" or "down").
+func parseMigrationFilename(name string) (version int64, short string, kind string, ok bool) {
+	switch {
+	case strings.HasSuffix(name, ".up.sql"):
+		kind = "up"
+		name = strings.TrimSuffix(name, ".up.sql")
+	case strings.HasSuffix(name, ".down.sql"):
+		kind = "down"
+		name = strings.TrimSuffix(name, ".down.sql")
+	default:
+		return 0, "", "", false
+	}
+
+	idx := strings.Index(name, "_")
+	if idx < 0 {
+		return 0, "", "", false
+	}
+	v, err := strconv.ParseInt(name[:idx], 10, 64)
+	if err != nil {
+		return 0, "", "", false
+	}
+	return v, name[idx+1:], kind, true
+}
+
+// PrepDatabaseWithMigrations applies migrator's pending migrations to db, then inserts
+// initialData the same way PrepDatabase does. It returns an error if either step fails.
+func PrepDatabaseWithMigrations(t testing.TB, db *sql.DB, migrator Migrator, initialData ...string) error {
+	t.Helper()
+
+	if err := migrator.Up(context.Background(), db); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	if len(initialData) == 0 {
+		return nil
+	}
+	return PrepDatabase(t, db, InitialDBSetup{InitialData: initialData})
+}
@@ -0,0 +1,32 @@
+package sqltest
+
+import "testing"
+
+func TestParseMigrationFilename(t *testing.T) {
+	version, name, kind, ok := parseMigrationFilename("001_create_users.up.sql")
+	if !ok {
+		t.Fatalf("parseMigrationFilename() ok = false, want true")
+	}
+	if version != 1 {
+		t.Errorf("version = %d, want 1", version)
+	}
+	if name != "create_users" {
+		t.Errorf("name = %q, want %q", name, "create_users")
+	}
+	if kind != "up" {
+		t.Errorf("kind = %q, want %q", kind, "up")
+	}
+
+	_, _, kind, ok = parseMigrationFilename("002_create_users.down.sql")
+	if !ok || kind != "down" {
+		t.Errorf("parseMigrationFilename(down) = (kind=%q, ok=%v), want (down, true)", kind, ok)
+	}
+
+	if _, _, _, ok := parseMigrationFilename("README.md"); ok {
+		t.Errorf("parseMigrationFilename(README.md) ok = true, want false")
+	}
+
+	if _, _, _, ok := parseMigrationFilename("not_a_version.up.sql"); ok {
+		t.Errorf("parseMigrationFilename(not_a_version.up.sql) ok = true, want false")
+	}
+}
@@ -0,0 +1,98 @@
+package sqltest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultMongoImage    = "mongo"
+	defaultMongoTag      = "7"
+	defaultMongoDatabase = "test"
+)
+
+// NewMongo starts a MongoDB Docker container using the default settings and returns a connected
+// *mongo.Client along with a cleanup function. It uses the default MongoDB image ("mongo") with
+// tag "7". For more customization, use NewMongoWithOptions.
+func NewMongo(t testing.TB) (*mongo.Client, func()) {
+	return NewMongoWithOptions(t, nil)
+}
+
+// NewMongoWithOptions starts a MongoDB Docker container using Docker and returns a connected
+// *mongo.Client along with a cleanup function. It applies the default settings:
+//   - Repository: "mongo"
+//   - Tag: "7"
+//
+// Additional RunOption functions can be provided via the runOpts parameter to override these defaults,
+// and optional host configuration functions can be provided via hostOpts.
+func NewMongoWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) (*mongo.Client, func()) {
+	t.Helper()
+
+	// Set default run options for MongoDB.
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultMongoImage,
+		Tag:        defaultMongoTag,
+	}
+
+	// Apply any provided RunOption functions to override defaults.
+	for _, opt := range runOpts {
+		opt(defaultRunOpts)
+	}
+
+	var client *mongo.Client
+	readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+		uri := fmt.Sprintf("mongodb://%s", resource.GetHostPort("27017/tcp"))
+		var err error
+		client, err = mongo.Connect(ctx, options.Client().ApplyURI(uri))
+		if err != nil {
+			return err
+		}
+		return client.Ping(ctx, nil)
+	}
+
+	pool, resource, _ := startContainerFromConfig(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "27017/tcp",
+	}, readyFunc)
+
+	cleanup := func() {
+		if err := client.Disconnect(context.Background()); err != nil {
+			t.Logf("failed to disconnect mongo client: %s", err)
+		}
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove mongo container: %s", err)
+		}
+	}
+
+	return client, cleanup
+}
+
+// PrepMongo seeds documents into the "test" database. data maps a collection name to the
+// documents that should be inserted into it.
+func PrepMongo(t testing.TB, client *mongo.Client, data map[string][]bson.M) error {
+	t.Helper()
+
+	ctx := context.Background()
+	db := client.Database(defaultMongoDatabase)
+	for collName, docs := range data {
+		if len(docs) == 0 {
+			continue
+		}
+		values := make([]interface{}, len(docs))
+		for i, doc := range docs {
+			values[i] = doc
+		}
+		if _, err := db.Collection(collName).InsertMany(ctx, values); err != nil {
+			return fmt.Errorf("failed to insert documents into collection '%s': %w", collName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package sqltest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/vvatanabe/sqltest"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestDefaultMongo demonstrates using NewMongo with default options.
+func TestDefaultMongo(t *testing.T) {
+	// Start a MongoDB container with default options.
+	client, cleanup := sqltest.NewMongo(t)
+	defer cleanup()
+
+	// Initial documents to store per collection.
+	initialData := map[string][]bson.M{
+		"users": {
+			{"_id": 1, "name": "Alice", "email": "alice@example.com"},
+			{"_id": 2, "name": "Bob", "email": "bob@example.com"},
+		},
+	}
+
+	// Prepare MongoDB by storing initial documents.
+	if err := sqltest.PrepMongo(t, client, initialData); err != nil {
+		t.Fatalf("PrepMongo failed: %v", err)
+	}
+
+	// Validate that the documents were stored correctly.
+	var doc bson.M
+	err := client.Database("test").Collection("users").FindOne(context.Background(), bson.M{"_id": 1}).Decode(&doc)
+	if err != nil {
+		t.Fatalf("failed to retrieve document: %v", err)
+	}
+	if doc["name"] != "Alice" {
+		t.Errorf("expected name 'Alice', but got '%v'", doc["name"])
+	}
+}
+
+// TestMongoWithCustomRunOptions demonstrates overriding default RunOptions.
+func TestMongoWithCustomRunOptions(t *testing.T) {
+	// Custom RunOption to override the default tag.
+	customTag := func(opts *dockertest.RunOptions) {
+		opts.Tag = "6"
+	}
+
+	// Start a MongoDB container with a custom tag.
+	client, cleanup := sqltest.NewMongoWithOptions(t, []sqltest.RunOption{customTag})
+	defer cleanup()
+
+	_, err := client.Database("test").Collection("products").InsertOne(context.Background(), bson.M{
+		"_id": 123, "name": "Widget", "price": 19.99,
+	})
+	if err != nil {
+		t.Fatalf("failed to insert document: %v", err)
+	}
+}
+
+// TestMongoWithCustomHostOptions demonstrates providing host configuration options.
+func TestMongoWithCustomHostOptions(t *testing.T) {
+	// Host option to set AutoRemove to true.
+	autoRemove := func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+	}
+
+	// Start a MongoDB container with the AutoRemove option.
+	client, cleanup := sqltest.NewMongoWithOptions(t, nil, autoRemove)
+	defer cleanup()
+
+	initialData := map[string][]bson.M{
+		"sessions": {
+			{"_id": "abc123", "user_id": 456},
+		},
+	}
+	if err := sqltest.PrepMongo(t, client, initialData); err != nil {
+		t.Fatalf("PrepMongo failed: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+// seedDatabase is the name of the Postgres template database that Pool clones per checkout.
+// MySQL pools do not use it; they replay the cached schema into each new database instead.
+const seedDatabase = "seed"
+
+// Pool starts a single database container for an engine and hands out fresh, isolated
+// databases to individual tests via Checkout, which is far cheaper than starting a new
+// container per test. Its constructors take a testing.TB, so a Pool is created from within a
+// test and can then be shared with later tests of the package. For Postgres, Pool creates each checkout
+// by cloning a "seed" template database (Postgres' CREATE DATABASE ... TEMPLATE). For MySQL,
+// Pool caches the compiled schema SQL and replays it into each freshly created database.
+type Pool struct {
+	driverName string
+	adminDB    *sql.DB
+	dsnFunc    func(dbName string) string
+	cleanup    func()
+
+	seedOnce sync.Once
+	seedErr  error
+	seedSQL  string
+
+	counter int64
+}
+
+// NewMySQLPool starts a MySQL Docker container using the default settings and returns a Pool
+// that hands out isolated databases via Checkout. For more customization, use
+// NewMySQLPoolWithOptions.
+func NewMySQLPool(t testing.TB) *Pool {
+	return NewMySQLPoolWithOptions(t, nil)
+}
+
+// NewMySQLPoolWithOptions starts a MySQL Docker container and returns a Pool that hands out
+// isolated databases via Checkout. runOpts and hostOpts are applied the same way as in
+// NewMySQLWithOptions.
+func NewMySQLPoolWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) *Pool {
+	t.Helper()
+
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultMySQLImage,
+		Tag:        defaultMySQLTag,
+		Env: []string{
+			"MYSQL_ROOT_PASSWORD=secret",
+			"MYSQL_DATABASE=test",
+		},
+	}
+	for _, opt := range runOpts {
+		opt(defaultRunOpts)
+	}
+	pass := getEnvValue(defaultRunOpts.Env, "MYSQL_ROOT_PASSWORD")
+	dbName := getEnvValue(defaultRunOpts.Env, "MYSQL_DATABASE")
+
+	var dsnFunc func(dbName string) string
+	var adminDB *sql.DB
+	readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+		actualPort := resource.GetHostPort("3306/tcp")
+		dsnFunc = func(name string) string {
+			return fmt.Sprintf("root:%s@tcp(%s)/%s?parseTime=true&multiStatements=true", pass, actualPort, name)
+		}
+		var err error
+		if adminDB, err = sql.Open("mysql", dsnFunc(dbName)); err != nil {
+			return err
+		}
+		return adminDB.PingContext(ctx)
+	}
+
+	pool, resource, _ := startContainerFromConfig(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "3306/tcp",
+	}, readyFunc)
+
+	cleanup := func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove mysql container: %s", err)
+		}
+	}
+
+	return &Pool{driverName: "mysql", adminDB: adminDB, dsnFunc: dsnFunc, cleanup: cleanup}
+}
+
+// NewPostgresPool starts a PostgreSQL Docker container using the default settings and returns a
+// Pool that hands out isolated databases via Checkout. For more customization, use
+// NewPostgresPoolWithOptions.
+func NewPostgresPool(t testing.TB) *Pool {
+	return NewPostgresPoolWithOptions(t, nil)
+}
+
+// NewPostgresPoolWithOptions starts a PostgreSQL Docker container and returns a Pool that hands
+// out isolated databases via Checkout. runOpts and hostOpts are applied the same way as in
+// NewPostgresWithOptions.
+func NewPostgresPoolWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) *Pool {
+	t.Helper()
+
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultPostgresImage,
+		Tag:        defaultPostgresTag,
+		Env: []string{
+			"POSTGRES_PASSWORD=secret",
+			"POSTGRES_DB=test",
+		},
+	}
+	for _, opt := range runOpts {
+		opt(defaultRunOpts)
+	}
+	pass := getEnvValue(defaultRunOpts.Env, "POSTGRES_PASSWORD")
+	dbName := getEnvValue(defaultRunOpts.Env, "POSTGRES_DB")
+
+	var dsnFunc func(dbName string) string
+	var adminDB *sql.DB
+	readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+		actualPort := resource.GetHostPort("5432/tcp")
+		dsnFunc = func(name string) string {
+			return fmt.Sprintf("postgres://postgres:%s@%s/%s?sslmode=disable", pass, actualPort, name)
+		}
+		var err error
+		if adminDB, err = sql.Open("postgres", dsnFunc(dbName)); err != nil {
+			return err
+		}
+		return adminDB.PingContext(ctx)
+	}
+
+	pool, resource, _ := startContainerFromConfig(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "5432/tcp",
+	}, readyFunc)
+
+	cleanup := func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove postgres container: %s", err)
+		}
+	}
+
+	return &Pool{driverName: "postgres", adminDB: adminDB, dsnFunc: dsnFunc, cleanup: cleanup}
+}
+
+// Checkout provisions a fresh, isolated database seeded with setup and returns a connected
+// *sql.DB. The database is dropped automatically via t.Cleanup. The first call to Checkout
+// compiles setup.SchemaSQL into the Pool's seed (Postgres: a template database; MySQL: a cached
+// DDL script); subsequent calls reuse that compiled schema regardless of the setup passed in.
+func (p *Pool) Checkout(t testing.TB, setup InitialDBSetup) *sql.DB {
+	t.Helper()
+
+	p.seedOnce.Do(func() {
+		p.seedSQL = setup.SchemaSQL
+		if p.driverName == "postgres" {
+			p.seedErr = p.seedPostgresTemplate(setup.SchemaSQL)
+		}
+		// MySQL has no template-database equivalent; the DDL is simply cached above and
+		// replayed into each freshly created database below.
+	})
+	if p.seedErr != nil {
+		t.Fatalf("failed to seed %s template: %s", p.driverName, p.seedErr)
+	}
+
+	n := atomic.AddInt64(&p.counter, 1)
+	dbName := fmt.Sprintf("test_%d", n)
+
+	switch p.driverName {
+	case "postgres":
+		if _, err := p.adminDB.Exec(fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", dbName, seedDatabase)); err != nil {
+			t.Fatalf("failed to create database %q from template: %s", dbName, err)
+		}
+	case "mysql":
+		if _, err := p.adminDB.Exec(fmt.Sprintf("CREATE DATABASE %s", dbName)); err != nil {
+			t.Fatalf("failed to create database %q: %s", dbName, err)
+		}
+	}
+
+	db, err := sql.Open(p.driverName, p.dsnFunc(dbName))
+	if err != nil {
+		t.Fatalf("failed to open %s database %q: %s", p.driverName, dbName, err)
+	}
+
+	if p.driverName == "mysql" && p.seedSQL != "" {
+		if _, err := db.Exec(p.seedSQL); err != nil {
+			t.Fatalf("failed to replay cached schema into %q: %s", dbName, err)
+		}
+	}
+
+	if err := PrepDatabase(t, db, InitialDBSetup{InitialData: setup.InitialData, Fixtures: setup.Fixtures}); err != nil {
+		t.Fatalf("failed to seed initial data into %q: %s", dbName, err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Logf("failed to close %s database %q: %s", p.driverName, dbName, err)
+		}
+		if _, err := p.adminDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", dbName)); err != nil {
+			t.Logf("failed to drop %s database %q: %s", p.driverName, dbName, err)
+		}
+	})
+
+	return db
+}
+
+// seedPostgresTemplate creates the "seed" database, applies schemaSQL to it once, and marks it
+// as a template so subsequent Checkout calls can clone it with CREATE DATABASE ... TEMPLATE.
+func (p *Pool) seedPostgresTemplate(schemaSQL string) error {
+	if _, err := p.adminDB.Exec(fmt.Sprintf("CREATE DATABASE %s", seedDatabase)); err != nil {
+		return fmt.Errorf("failed to create seed database: %w", err)
+	}
+
+	if schemaSQL != "" {
+		seedDB, err := sql.Open("postgres", p.dsnFunc(seedDatabase))
+		if err != nil {
+			return fmt.Errorf("failed to open seed database: %w", err)
+		}
+		defer seedDB.Close()
+
+		if _, err := seedDB.Exec(schemaSQL); err != nil {
+			return fmt.Errorf("failed to apply schema to seed database: %w", err)
+		}
+	}
+
+	if _, err := p.adminDB.Exec(fmt.Sprintf("UPDATE pg_database SET datistemplate = true WHERE datname = '%s'", seedDatabase)); err != nil {
+		return fmt.Errorf("failed to mark seed database as template: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the Pool's admin connection and purges its underlying container.
+func (p *Pool) Close() {
+	_ = p.adminDB.Close()
+	p.cleanup()
+}
@@ -0,0 +1,73 @@
+package sqltest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/vvatanabe/sqltest"
+)
+
+// mysqlPool is shared across the tests in this file so only one MySQL container is started
+// for the whole file, instead of one per test. TestMain closes it once every test has run.
+var mysqlPool *sqltest.Pool
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if mysqlPool != nil {
+		mysqlPool.Close()
+	}
+	os.Exit(code)
+}
+
+// TestPoolCheckout demonstrates checking out an isolated database from a shared Pool.
+func TestPoolCheckout(t *testing.T) {
+	if mysqlPool == nil {
+		mysqlPool = sqltest.NewMySQLPool(t)
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS users (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL
+	);
+	`
+	insertStmt := `INSERT INTO users (name) VALUES ('Dana');`
+
+	db := mysqlPool.Checkout(t, sqltest.InitialDBSetup{
+		SchemaSQL:   schema,
+		InitialData: []string{insertStmt},
+	})
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE name = ?", "Dana").Scan(&name); err != nil {
+		t.Fatalf("failed to retrieve data: %v", err)
+	}
+	if name != "Dana" {
+		t.Errorf("expected name 'Dana', but got '%s'", name)
+	}
+}
+
+// TestPoolCheckoutIsolated demonstrates that each checkout is an independent database: data
+// inserted in one checkout is invisible to another.
+func TestPoolCheckoutIsolated(t *testing.T) {
+	if mysqlPool == nil {
+		mysqlPool = sqltest.NewMySQLPool(t)
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS users (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL
+	);
+	`
+
+	db := mysqlPool.Checkout(t, sqltest.InitialDBSetup{SchemaSQL: schema})
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected a fresh database with 0 rows, but got %d", count)
+	}
+}
@@ -0,0 +1,90 @@
+package sqltest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultRedisImage = "redis"
+	defaultRedisTag   = "7"
+)
+
+// NewRedis starts a Redis Docker container using the default settings and returns a connected *redis.Client
+// along with a cleanup function. It uses the default Redis image ("redis") with tag "7". For more
+// customization, use NewRedisWithOptions.
+func NewRedis(t testing.TB) (*redis.Client, func()) {
+	return NewRedisWithOptions(t, nil)
+}
+
+// NewRedisWithOptions starts a Redis Docker container using Docker and returns a connected *redis.Client
+// along with a cleanup function. It applies the default settings:
+//   - Repository: "redis"
+//   - Tag: "7"
+//
+// Additional RunOption functions can be provided via the runOpts parameter to override these defaults,
+// and optional host configuration functions can be provided via hostOpts.
+func NewRedisWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(*docker.HostConfig)) (*redis.Client, func()) {
+	t.Helper()
+
+	// Set default run options for Redis.
+	defaultRunOpts := &dockertest.RunOptions{
+		Repository: defaultRedisImage,
+		Tag:        defaultRedisTag,
+	}
+
+	// Apply any provided RunOption functions to override defaults.
+	for _, opt := range runOpts {
+		opt(defaultRunOpts)
+	}
+
+	// Create a redis client and wait until it responds to PING.
+	var client *redis.Client
+	readyFunc := func(ctx context.Context, resource *dockertest.Resource) error {
+		client = redis.NewClient(&redis.Options{Addr: resource.GetHostPort("6379/tcp")})
+		return client.Ping(ctx).Err()
+	}
+
+	pool, resource, _ := startContainerFromConfig(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "6379/tcp",
+	}, readyFunc)
+
+	cleanup := func() {
+		if err := client.Close(); err != nil {
+			t.Logf("failed to close redis client: %s", err)
+		}
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove redis container: %s", err)
+		}
+	}
+
+	return client, cleanup
+}
+
+// PrepRedis sets initial key-value pairs in the Redis instance. It takes a map of key-value pairs
+// and stores them in the cache. An optional ttls map can be provided to set a per-key expiration;
+// keys absent from ttls are stored without an expiration.
+func PrepRedis(t testing.TB, client *redis.Client, initialData map[string]string, ttls ...map[string]time.Duration) error {
+	t.Helper()
+
+	var ttl map[string]time.Duration
+	if len(ttls) > 0 {
+		ttl = ttls[0]
+	}
+
+	ctx := context.Background()
+	for key, value := range initialData {
+		if err := client.Set(ctx, key, value, ttl[key]).Err(); err != nil {
+			return fmt.Errorf("failed to set key '%s': %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package sqltest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/vvatanabe/sqltest"
+)
+
+// TestDefaultRedis demonstrates using NewRedis with default options.
+func TestDefaultRedis(t *testing.T) {
+	// Start a Redis container with default options.
+	client, cleanup := sqltest.NewRedis(t)
+	defer cleanup()
+
+	// Initial data to store in Redis.
+	initialData := map[string]string{
+		"user:1": `{"id": 1, "name": "Alice", "email": "alice@example.com"}`,
+		"user:2": `{"id": 2, "name": "Bob", "email": "bob@example.com"}`,
+	}
+
+	// Prepare Redis by storing initial data.
+	if err := sqltest.PrepRedis(t, client, initialData); err != nil {
+		t.Fatalf("PrepRedis failed: %v", err)
+	}
+
+	// Validate that the data was stored correctly.
+	got, err := client.Get(context.Background(), "user:1").Result()
+	if err != nil {
+		t.Fatalf("failed to retrieve data: %v", err)
+	}
+
+	expectedValue := `{"id": 1, "name": "Alice", "email": "alice@example.com"}`
+	if got != expectedValue {
+		t.Errorf("expected value '%s', but got '%s'", expectedValue, got)
+	}
+}
+
+// TestRedisWithCustomRunOptions demonstrates overriding default RunOptions.
+func TestRedisWithCustomRunOptions(t *testing.T) {
+	// Custom RunOption to override the default tag.
+	customTag := func(opts *dockertest.RunOptions) {
+		opts.Tag = "6"
+	}
+
+	// Start a Redis container with a custom tag.
+	client, cleanup := sqltest.NewRedisWithOptions(t, []sqltest.RunOption{customTag})
+	defer cleanup()
+
+	// Test setting and getting a value.
+	key := "product:123"
+	value := `{"id": 123, "name": "Widget", "price": 19.99}`
+
+	err := client.Set(context.Background(), key, value, 0).Err()
+	if err != nil {
+		t.Fatalf("failed to set value: %v", err)
+	}
+
+	// Validate the stored data.
+	got, err := client.Get(context.Background(), key).Result()
+	if err != nil {
+		t.Fatalf("failed to retrieve data: %v", err)
+	}
+
+	if got != value {
+		t.Errorf("expected value '%s', but got '%s'", value, got)
+	}
+}
+
+// TestRedisWithCustomHostOptions demonstrates providing host configuration options.
+func TestRedisWithCustomHostOptions(t *testing.T) {
+	// Host option to set AutoRemove to true.
+	autoRemove := func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+	}
+
+	// Start a Redis container with the AutoRemove option.
+	client, cleanup := sqltest.NewRedisWithOptions(t, nil, autoRemove)
+	defer cleanup()
+
+	// Prepare Redis with a per-key TTL.
+	initialData := map[string]string{
+		"session:abc123": "user_id=456&expires=2023-12-31",
+		"temp:xyz789":    "temporary data",
+	}
+	ttls := map[string]time.Duration{
+		"temp:xyz789": 60 * time.Second,
+	}
+
+	if err := sqltest.PrepRedis(t, client, initialData, ttls); err != nil {
+		t.Fatalf("PrepRedis failed: %v", err)
+	}
+
+	got, err := client.Get(context.Background(), "session:abc123").Result()
+	if err != nil {
+		t.Fatalf("failed to retrieve data: %v", err)
+	}
+	if got != initialData["session:abc123"] {
+		t.Errorf("expected value '%s', but got '%s'", initialData["session:abc123"], got)
+	}
+
+	ttl, err := client.TTL(context.Background(), "temp:xyz789").Result()
+	if err != nil {
+		t.Fatalf("failed to retrieve ttl: %v", err)
+	}
+	if ttl <= 0 {
+		t.Errorf("expected a positive ttl for 'temp:xyz789', but got %s", ttl)
+	}
+}
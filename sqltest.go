@@ -22,11 +22,87 @@ func init() {
 // RunOption is a function that modifies a dockertest.RunOptions.
 type RunOption func(*dockertest.RunOptions)
 
-// NewDockerDB starts a Docker container using the specified run options,
-// container port, driver name, and a function to generate the DSN.
-// Additionally, it accepts optional host configuration functions.
-// It returns a connected *sql.DB and a cleanup function.
-func NewDockerDB(t testing.TB, runOpts *dockertest.RunOptions, containerPort, driverName string, dsnFunc func(actualPort string) string, hostOpts ...func(*docker.HostConfig)) (*sql.DB, func()) {
+// ContainerConfig describes everything NewDockerDB needs to start a database container,
+// wait for it to become ready, and connect to it. It exists so readiness and lifecycle can be
+// customized per engine (e.g. CockroachDB, MariaDB, TimescaleDB) without duplicating the
+// retry/purge boilerplate in NewDockerDB itself.
+type ContainerConfig struct {
+	// RunOptions describes the image, tag, and environment to start the container with.
+	RunOptions *dockertest.RunOptions
+	// HostOpts are optional host configuration functions applied when starting the container.
+	HostOpts []func(*docker.HostConfig)
+	// ContainerPort is the container-side port (e.g. "3306/tcp") to resolve to a host port.
+	ContainerPort string
+	// DriverName is the database/sql driver name (e.g. "mysql", "postgres").
+	DriverName string
+	// DSNFunc builds the connection string from the resolved host port.
+	DSNFunc func(actualPort string) string
+	// ReadyFunc, if set, overrides the default readiness check. It is retried until it
+	// succeeds, MaxRetries is exhausted, or StartTimeout elapses. The default check opens a
+	// *sql.DB via DriverName/DSNFunc and pings it.
+	ReadyFunc func(ctx context.Context, resource *dockertest.Resource) error
+	// AfterReady, if set, runs once after the container is deemed ready, e.g. to run
+	// pg_isready, load extensions, or wait for replication lag to reach zero. An error here
+	// aborts startup the same way a failed readiness check does.
+	AfterReady func(ctx context.Context, resource *dockertest.Resource) error
+	// PullTimeout bounds how long starting the container (including pulling its image) may
+	// take. Zero means no bound beyond Docker's own behavior.
+	PullTimeout time.Duration
+	// StartTimeout bounds how long waiting for readiness may take. Defaults to 30 seconds.
+	StartTimeout time.Duration
+	// MaxRetries bounds how many times the readiness check is attempted. Zero means retry
+	// until StartTimeout elapses.
+	MaxRetries uint
+}
+
+// NewDockerDB starts a Docker container described by cfg, waits for it to become ready, and
+// returns a connected *sql.DB along with a cleanup function that closes the connection and
+// purges the container.
+func NewDockerDB(t testing.TB, cfg ContainerConfig) (*sql.DB, func()) {
+	t.Helper()
+
+	var db *sql.DB
+	readyFunc := cfg.ReadyFunc
+	if readyFunc == nil {
+		readyFunc = func(ctx context.Context, resource *dockertest.Resource) error {
+			var err error
+			db, err = sql.Open(cfg.DriverName, cfg.DSNFunc(resource.GetHostPort(cfg.ContainerPort)))
+			if err != nil {
+				return err
+			}
+			return db.PingContext(ctx)
+		}
+	}
+
+	pool, resource, actualPort := startContainerFromConfig(t, cfg, readyFunc)
+
+	if db == nil {
+		// A custom ReadyFunc signaled readiness without itself opening the connection.
+		var err error
+		if db, err = sql.Open(cfg.DriverName, cfg.DSNFunc(actualPort)); err != nil {
+			_ = pool.Purge(resource)
+			t.Fatalf("failed to open %s connection: %s", cfg.DriverName, err)
+		}
+	}
+
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			t.Logf("failed to close DB: %s", err)
+		}
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove %s container: %s", cfg.DriverName, err)
+		}
+	}
+
+	return db, cleanup
+}
+
+// startContainerFromConfig creates a fresh dockertest.Pool and starts cfg on it via
+// startContainerInPool. It's the entry point for constructors that own their container's pool
+// outright (NewDockerDB, Memcached, Redis, Mongo, DynamoDB Local, the Pool constructors, ...);
+// callers that share one pool across several containers (the matrix helpers) call
+// startContainerInPool directly instead.
+func startContainerFromConfig(t testing.TB, cfg ContainerConfig, readyFunc func(ctx context.Context, resource *dockertest.Resource) error) (*dockertest.Pool, *dockertest.Resource, string) {
 	t.Helper()
 
 	pool, err := dockertest.NewPool("")
@@ -34,44 +110,95 @@ func NewDockerDB(t testing.TB, runOpts *dockertest.RunOptions, containerPort, dr
 		t.Fatalf("failed to connect to docker: %s", err)
 	}
 
-	// Pass optional host configuration options.
-	resource, err := pool.RunWithOptions(runOpts, hostOpts...)
+	resource, actualPort := startContainerInPool(t, pool, cfg, readyFunc)
+	return pool, resource, actualPort
+}
+
+// startContainerInPool starts cfg.RunOptions on pool, resolves cfg.ContainerPort to a host port,
+// and retries readyFunc until it succeeds, cfg.MaxRetries is exhausted, or cfg.StartTimeout
+// elapses, then runs cfg.AfterReady. It has no opinion on what connects to the container, so it
+// backs every container-starting helper in the package, whether or not they own their pool.
+func startContainerInPool(t testing.TB, pool *dockertest.Pool, cfg ContainerConfig, readyFunc func(ctx context.Context, resource *dockertest.Resource) error) (*dockertest.Resource, string) {
+	t.Helper()
+
+	label := cfg.RunOptions.Repository
+
+	startTimeout := cfg.StartTimeout
+	if startTimeout == 0 {
+		startTimeout = 30 * time.Second
+	}
+
+	resource, err := runContainer(pool, cfg.RunOptions, cfg.PullTimeout, cfg.HostOpts...)
 	if err != nil {
-		t.Fatalf("failed to start %s container: %s", driverName, err)
+		t.Fatalf("failed to start %s container: %s", label, err)
 	}
 
-	actualPort := resource.GetHostPort(containerPort)
+	actualPort := resource.GetHostPort(cfg.ContainerPort)
 	if actualPort == "" {
 		_ = pool.Purge(resource)
-		t.Fatalf("no host port was assigned for the %s container", driverName)
+		t.Fatalf("no host port was assigned for the %s container", label)
 	}
-	t.Logf("%s container is running on host port '%s'", driverName, actualPort)
+	t.Logf("%s container is running on host port '%s'", label, actualPort)
 
-	var db *sql.DB
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
 	defer cancel()
-	if err = pool.Retry(func() error {
-		dsn := dsnFunc(actualPort)
-		db, err = sql.Open(driverName, dsn)
-		if err != nil {
-			return err
-		}
-		return db.PingContext(ctx)
-	}); err != nil {
+
+	if err := waitReady(ctx, cfg.MaxRetries, func() error { return readyFunc(ctx, resource) }); err != nil {
 		_ = pool.Purge(resource)
-		t.Fatalf("failed to connect to %s: %s", driverName, err)
+		t.Fatalf("failed to connect to %s: %s", label, err)
 	}
 
-	cleanup := func() {
-		if err := db.Close(); err != nil {
-			t.Logf("failed to close DB: %s", err)
-		}
-		if err := pool.Purge(resource); err != nil {
-			t.Logf("failed to remove %s container: %s", driverName, err)
+	if cfg.AfterReady != nil {
+		if err := cfg.AfterReady(ctx, resource); err != nil {
+			_ = pool.Purge(resource)
+			t.Fatalf("AfterReady hook failed for %s: %s", label, err)
 		}
 	}
 
-	return db, cleanup
+	return resource, actualPort
+}
+
+// runContainer starts runOpts via pool.RunWithOptions, bailing out after pullTimeout if it
+// takes too long. Note that the underlying Docker pull/start call isn't itself cancellable, so
+// on timeout we simply stop waiting on it rather than aborting it.
+func runContainer(pool *dockertest.Pool, runOpts *dockertest.RunOptions, pullTimeout time.Duration, hostOpts ...func(*docker.HostConfig)) (*dockertest.Resource, error) {
+	if pullTimeout <= 0 {
+		return pool.RunWithOptions(runOpts, hostOpts...)
+	}
+
+	type result struct {
+		resource *dockertest.Resource
+		err      error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		resource, err := pool.RunWithOptions(runOpts, hostOpts...)
+		ch <- result{resource, err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.resource, res.err
+	case <-time.After(pullTimeout):
+		return nil, fmt.Errorf("timed out after %s starting %s container", pullTimeout, runOpts.Repository)
+	}
+}
+
+// waitReady retries readyFunc until it succeeds, maxRetries attempts are exhausted (0 means
+// unbounded), or ctx is done, whichever comes first.
+func waitReady(ctx context.Context, maxRetries uint, readyFunc func() error) error {
+	var err error
+	for attempt := uint(0); maxRetries == 0 || attempt < maxRetries; attempt++ {
+		if err = readyFunc(); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Second):
+		}
+	}
+	return err
 }
 
 // NewMySQL starts a MySQL Docker container using the default settings and returns a connected *sql.DB
@@ -116,9 +243,15 @@ func NewMySQLWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(*do
 	pass := getEnvValue(defaultRunOpts.Env, "MYSQL_ROOT_PASSWORD")
 	db := getEnvValue(defaultRunOpts.Env, "MYSQL_DATABASE")
 
-	return NewDockerDB(t, defaultRunOpts, "3306/tcp", "mysql", func(actualPort string) string {
-		return fmt.Sprintf("root:%s@tcp(%s)/%s?parseTime=true", pass, actualPort, db)
-	}, hostOpts...)
+	return NewDockerDB(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "3306/tcp",
+		DriverName:    "mysql",
+		DSNFunc: func(actualPort string) string {
+			return fmt.Sprintf("root:%s@tcp(%s)/%s?parseTime=true", pass, actualPort, db)
+		},
+	})
 }
 
 const (
@@ -163,9 +296,15 @@ func NewPostgresWithOptions(t testing.TB, runOpts []RunOption, hostOpts ...func(
 	pass := getEnvValue(defaultRunOpts.Env, "POSTGRES_PASSWORD")
 	db := getEnvValue(defaultRunOpts.Env, "POSTGRES_DB")
 
-	return NewDockerDB(t, defaultRunOpts, "5432/tcp", "postgres", func(actualPort string) string {
-		return fmt.Sprintf("postgres://postgres:%s@%s/%s?sslmode=disable", pass, actualPort, db)
-	}, hostOpts...)
+	return NewDockerDB(t, ContainerConfig{
+		RunOptions:    defaultRunOpts,
+		HostOpts:      hostOpts,
+		ContainerPort: "5432/tcp",
+		DriverName:    "postgres",
+		DSNFunc: func(actualPort string) string {
+			return fmt.Sprintf("postgres://postgres:%s@%s/%s?sslmode=disable", pass, actualPort, db)
+		},
+	})
 }
 
 // InitialDBSetup is used to set up the database before tests.
@@ -176,6 +315,10 @@ type InitialDBSetup struct {
 	SchemaSQL string
 	// InitialData contains SQL statements for seeding initial data.
 	InitialData []string
+	// Fixtures are row-level seeds applied after InitialData, typically loaded via
+	// LoadFixtures. Unlike InitialData, each Fixture is inserted through a driver-aware
+	// builder, so the same fixture works unchanged against MySQL or Postgres.
+	Fixtures []Fixture
 }
 
 // PrepDatabase executes the provided schema and initial data SQL statements sequentially
@@ -205,6 +348,11 @@ func PrepDatabase(t testing.TB, db *sql.DB, setups ...InitialDBSetup) error {
 				return fmt.Errorf("failed to commit transaction: %w", err)
 			}
 		}
+		for _, fixture := range setup.Fixtures {
+			if err := applyFixture(db, fixture); err != nil {
+				return fmt.Errorf("failed to apply fixture for table %q: %w", fixture.Table, err)
+			}
+		}
 	}
 	return nil
 }